@@ -0,0 +1,216 @@
+// Command fake-prometheus serves fake hardware exporters for Prometheus to
+// scrape. Each fake exporter (IPMI, DCGM, ...) is a FakeCollector. IPMI and
+// GPU collectors are registered into separate prometheus.Registry instances
+// and served on separate HTTP paths, so scrapes of one never race with or
+// leak into the other's; with --gpu-vendor=mixed the DCGM and AMD collectors
+// share the GPU registry and path. A --topology file
+// can describe a whole fleet of synthetic nodes served from one process,
+// selected per scrape with a Prometheus blackbox-exporter-style ?target=
+// query parameter.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/thediymaker/fake-prometheus/pkg/collectors"
+	"github.com/thediymaker/fake-prometheus/pkg/scenario"
+	"github.com/thediymaker/fake-prometheus/pkg/topology"
+	"github.com/thediymaker/fake-prometheus/pkg/workload"
+)
+
+const listenAddr = ":9290"
+
+// tickInterval is how often the scenario engine rolls state transitions and
+// the workload scheduler considers new arrivals/departures. It's
+// independent of, and much more frequent than, scrape intervals.
+const tickInterval = time.Second
+
+// gpuVendor selects which fake GPU personality(-ies) a node's /gpu/metrics
+// serves.
+type gpuVendor string
+
+const (
+	vendorNvidia gpuVendor = "nvidia"
+	vendorAMD    gpuVendor = "amd"
+	vendorMixed  gpuVendor = "mixed"
+)
+
+// node bundles one topology node's collectors and workload resource ids
+// behind the HTTP handlers registered for it.
+type node struct {
+	hostname    string
+	ipmi        http.Handler
+	gpu         http.Handler
+	resourceIDs []string
+}
+
+func main() {
+	scenarioPath := flag.String("scenario", "", "path to a scenario YAML/JSON file driving component states (optional)")
+	topologyPath := flag.String("topology", "", "path to a topology YAML/JSON file describing a multi-node cluster (optional; defaults to a single node matching this host)")
+	vendorFlag := flag.String("gpu-vendor", string(vendorNvidia), "fake GPU personality to serve when --topology is not given: nvidia, amd, or mixed")
+	flag.Parse()
+
+	rand.Seed(time.Now().UnixNano())
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Fatal("Failed to get hostname:", err)
+	}
+
+	var topoCfg topology.Config
+	if *topologyPath != "" {
+		cfg, err := topology.LoadConfig(*topologyPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		topoCfg = *cfg
+	} else {
+		topoCfg = topology.DefaultConfig(hostname)
+		topoCfg.Nodes[0].GPUs.Vendor = *vendorFlag
+	}
+
+	var engine *scenario.Engine
+	if *scenarioPath != "" {
+		cfg, err := scenario.LoadConfig(*scenarioPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		engine = scenario.NewEngine(*cfg)
+		go runScenario(engine)
+	}
+
+	scheduler := workload.NewScheduler()
+
+	// componentPrefix is only added once there's more than one node, so a
+	// single-node deployment's scenario/workload component ids (and
+	// existing scenario files written against them) are unaffected.
+	namespaced := len(topoCfg.Nodes) > 1
+
+	nodes := make([]node, len(topoCfg.Nodes))
+	var allResourceIDs []string
+	for i, nc := range topoCfg.Nodes {
+		prefix := ""
+		if namespaced {
+			prefix = nc.Hostname + "/"
+		}
+
+		gpuCollectors := buildGPUCollectors(gpuVendor(nc.GPUs.Vendor), nc, prefix, engine, scheduler)
+		resourceIDs := nodeResourceIDs(nc, prefix)
+
+		nodes[i] = node{
+			hostname:    nc.Hostname,
+			ipmi:        collectorHandler(collectors.NewIPMICollector(nc, prefix, engine, scheduler)),
+			gpu:         collectorHandler(gpuCollectors...),
+			resourceIDs: resourceIDs,
+		}
+		allResourceIDs = append(allResourceIDs, resourceIDs...)
+	}
+
+	go runScheduler(scheduler, allResourceIDs)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ipmi/metrics", dispatch(nodes, func(n node) http.Handler { return n.ipmi }))
+	mux.HandleFunc("/gpu/metrics", dispatch(nodes, func(n node) http.Handler { return n.gpu }))
+	if engine != nil {
+		mux.HandleFunc("/admin/inject", scenario.AdminHandler(engine))
+	}
+
+	fmt.Printf("Starting fake metrics exporter for %d node(s) on %s\n", len(nodes), listenAddr)
+	fmt.Println("Access IPMI metrics at http://localhost" + listenAddr + "/ipmi/metrics")
+	fmt.Println("Access GPU metrics at http://localhost" + listenAddr + "/gpu/metrics")
+	log.Fatal(http.ListenAndServe(listenAddr, mux))
+}
+
+// buildGPUCollectors returns the FakeCollectors node's /gpu/metrics should
+// serve for the given vendor personality.
+func buildGPUCollectors(vendor gpuVendor, nc topology.NodeConfig, componentPrefix string, engine *scenario.Engine, scheduler *workload.Scheduler) []collectors.FakeCollector {
+	switch vendor {
+	case vendorNvidia:
+		return []collectors.FakeCollector{collectors.NewDCGMCollector(nc, componentPrefix, engine, scheduler)}
+	case vendorAMD:
+		return []collectors.FakeCollector{collectors.NewAMDCollector(nc, componentPrefix, engine, scheduler)}
+	case vendorMixed:
+		return []collectors.FakeCollector{
+			collectors.NewDCGMCollector(nc, componentPrefix, engine, scheduler),
+			collectors.NewAMDCollector(nc, componentPrefix, engine, scheduler),
+		}
+	default:
+		log.Fatalf("node %s: unknown gpu vendor %q: must be nvidia, amd, or mixed", nc.Hostname, vendor)
+		return nil
+	}
+}
+
+// nodeResourceIDs returns the workload scheduler resource ids for nc's GPUs
+// and power draw, matching the component ids its collectors look themselves
+// up under.
+func nodeResourceIDs(nc topology.NodeConfig, componentPrefix string) []string {
+	var ids []string
+	vendor := gpuVendor(nc.GPUs.Vendor)
+	for i := 0; i < nc.GPUs.Count; i++ {
+		gpuNum := fmt.Sprintf("%d", i)
+		if vendor == vendorNvidia || vendor == vendorMixed {
+			ids = append(ids, componentPrefix+"gpu"+gpuNum)
+		}
+		if vendor == vendorAMD || vendor == vendorMixed {
+			ids = append(ids, componentPrefix+"amd-gpu"+gpuNum)
+		}
+	}
+	return append(ids, componentPrefix+"ipmi-power")
+}
+
+// runScenario ticks engine forever at tickInterval.
+func runScenario(engine *scenario.Engine) {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		engine.Tick()
+	}
+}
+
+// runScheduler ticks scheduler forever at tickInterval, so workloads arrive
+// at and exit from every node's GPUs and power draw over time.
+func runScheduler(scheduler *workload.Scheduler, resourceIDs []string) {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		scheduler.Tick(resourceIDs)
+	}
+}
+
+// collectorHandler wires one or more FakeCollectors into their own shared
+// registry and returns it as a handler, so new exporters can be added here
+// without touching the rest of main.
+func collectorHandler(cs ...collectors.FakeCollector) http.Handler {
+	registry := prometheus.NewRegistry()
+	for _, c := range cs {
+		registry.MustRegister(c)
+	}
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// dispatch returns a handler that serves the node named by the "target"
+// query parameter, the way the blackbox and snmp exporters serve many
+// targets from one process. With no target, it falls back to the first
+// configured node; a target matching no node's hostname gets a 404, even in
+// a single-node topology.
+func dispatch(nodes []node, pick func(node) http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := r.URL.Query().Get("target")
+		for _, n := range nodes {
+			if target == "" || n.hostname == target {
+				pick(n).ServeHTTP(w, r)
+				return
+			}
+		}
+		http.Error(w, fmt.Sprintf("unknown target %q", target), http.StatusNotFound)
+	}
+}
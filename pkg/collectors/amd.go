@@ -0,0 +1,169 @@
+package collectors
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/thediymaker/fake-prometheus/pkg/scenario"
+	"github.com/thediymaker/fake-prometheus/pkg/topology"
+	"github.com/thediymaker/fake-prometheus/pkg/workload"
+)
+
+// amdTotalMemoryBytes is the frame buffer size reported for each simulated
+// AMD GPU (64GiB, matching an Instinct MI210).
+const amdTotalMemoryBytes = 64 * 1024 * 1024 * 1024
+
+var amdLabels = []string{"gpu", "node", "pod"}
+
+// amdGPU describes one simulated AMD GPU's identity labels.
+type amdGPU struct {
+	gpu  string
+	node string
+}
+
+// buildAMDGPUs describes node's simulated AMD GPU fleet.
+func buildAMDGPUs(node topology.NodeConfig) []amdGPU {
+	gpus := make([]amdGPU, node.GPUs.Count)
+	for i := 0; i < node.GPUs.Count; i++ {
+		gpus[i] = amdGPU{gpu: fmt.Sprintf("%d", i), node: node.Hostname}
+	}
+	return gpus
+}
+
+// amdComponent returns the scenario/workload component id for an AMD GPU,
+// e.g. "amd-gpu0" or, on a namespaced node, "g002/amd-gpu0".
+func (c *AMDCollector) amdComponent(gpuNum string) string {
+	return c.componentPrefix + "amd-gpu" + gpuNum
+}
+
+// AMDCollector generates fake amd-smi-exporter-style metrics for a fixed
+// fleet of AMD GPUs at scrape time, for simulating clusters with AMD
+// Instinct accelerators alongside (or instead of) NVIDIA ones.
+type AMDCollector struct {
+	// mu guards prevTemp against concurrent Collect calls from simultaneous
+	// scrapes of the same registry.
+	mu sync.Mutex
+
+	temp        *prometheus.Desc
+	sclk        *prometheus.Desc
+	mclk        *prometheus.Desc
+	usePercent  *prometheus.Desc
+	memoryUsed  *prometheus.Desc
+	memoryTotal *prometheus.Desc
+	memoryBusy  *prometheus.Desc
+	power       *prometheus.Desc
+	gpus        []amdGPU
+
+	// componentPrefix namespaces this node's scenario/workload component ids
+	// (e.g. "g002/") for multi-node topologies. Empty for a single-node
+	// deployment.
+	componentPrefix string
+
+	engine    *scenario.Engine
+	scheduler *workload.Scheduler
+	prevTemp  map[string]float64
+}
+
+// NewAMDCollector returns an AMDCollector for node's configured AMD GPU
+// fleet. componentPrefix namespaces this node's scenario/workload component
+// ids for multi-node topologies; pass "" for a single-node deployment.
+// engine and scheduler may be nil, in which case GPUs fall back to plain
+// random sampling and report no pod attribution.
+func NewAMDCollector(node topology.NodeConfig, componentPrefix string, engine *scenario.Engine, scheduler *workload.Scheduler) *AMDCollector {
+	gpus := buildAMDGPUs(node)
+	prevTemp := make(map[string]float64, len(gpus))
+	for _, gpu := range gpus {
+		prevTemp[gpu.gpu] = 35
+	}
+
+	return &AMDCollector{
+		temp:            prometheus.NewDesc("amd_gpu_temp", "GPU temperature (in C).", amdLabels, nil),
+		sclk:            prometheus.NewDesc("amd_gpu_sclk", "GPU clock frequency (in MHz).", amdLabels, nil),
+		mclk:            prometheus.NewDesc("amd_gpu_mclk", "Memory clock frequency (in MHz).", amdLabels, nil),
+		usePercent:      prometheus.NewDesc("amd_gpu_use_percent", "GPU utilization (in %).", amdLabels, nil),
+		memoryUsed:      prometheus.NewDesc("amd_gpu_memory_used", "GPU memory used (in bytes).", amdLabels, nil),
+		memoryTotal:     prometheus.NewDesc("amd_gpu_memory_total", "GPU memory total (in bytes).", amdLabels, nil),
+		memoryBusy:      prometheus.NewDesc("amd_gpu_memory_busy_percent", "Percent of time the memory controller is busy.", amdLabels, nil),
+		power:           prometheus.NewDesc("amd_gpu_power", "Power draw (in W).", amdLabels, nil),
+		gpus:            gpus,
+		componentPrefix: componentPrefix,
+		engine:          engine,
+		scheduler:       scheduler,
+		prevTemp:        prevTemp,
+	}
+}
+
+// Name implements FakeCollector.
+func (c *AMDCollector) Name() string { return "amd-gpu" }
+
+// Describe implements prometheus.Collector.
+func (c *AMDCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.temp
+	ch <- c.sclk
+	ch <- c.mclk
+	ch <- c.usePercent
+	ch <- c.memoryUsed
+	ch <- c.memoryTotal
+	ch <- c.memoryBusy
+	ch <- c.power
+}
+
+// Collect implements prometheus.Collector, generating a full set of fake
+// readings for this scrape.
+func (c *AMDCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, gpu := range c.gpus {
+		var wl workload.Workload
+		if c.scheduler != nil {
+			wl = c.scheduler.Assignment(c.amdComponent(gpu.gpu))
+		}
+		labels := []string{gpu.gpu, gpu.node, wl.Pod}
+
+		state := ""
+		if c.engine != nil {
+			state = c.engine.CurrentState(c.amdComponent(gpu.gpu))
+		}
+		isActive := rand.Float64() < 0.8
+		util := targetUtilization(state, isActive)
+
+		ch <- prometheus.MustNewConstMetric(c.usePercent, prometheus.GaugeValue, util, labels...)
+
+		if util > 50 {
+			ch <- prometheus.MustNewConstMetric(c.sclk, prometheus.GaugeValue, randomInRange(1400, 1700), labels...)
+		} else {
+			ch <- prometheus.MustNewConstMetric(c.sclk, prometheus.GaugeValue, randomInRange(300, 800), labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.mclk, prometheus.GaugeValue, randomInRange(1590, 1600), labels...)
+
+		targetTemp := 30.0 + (util * 0.5)
+		if state == "throttled" {
+			targetTemp += 20
+		}
+		temp := c.prevTemp[gpu.gpu]*0.7 + targetTemp*0.3
+		c.prevTemp[gpu.gpu] = temp
+		ch <- prometheus.MustNewConstMetric(c.temp, prometheus.GaugeValue, temp+randomInRange(-2, 2), labels...)
+
+		power := 50 + (500 * (temp - 30) / 0.5 / 100)
+		if state == "failed" {
+			power = 0
+		}
+		ch <- prometheus.MustNewConstMetric(c.power, prometheus.GaugeValue, power+randomInRange(-10, 10), labels...)
+
+		memBusy := 0.0
+		if isActive {
+			memBusy = randomInRange(10, 90)
+		} else {
+			memBusy = randomInRange(0, 5)
+		}
+		ch <- prometheus.MustNewConstMetric(c.memoryBusy, prometheus.GaugeValue, memBusy, labels...)
+
+		usedMem := amdTotalMemoryBytes * memBusy / 100
+		ch <- prometheus.MustNewConstMetric(c.memoryUsed, prometheus.GaugeValue, usedMem, labels...)
+		ch <- prometheus.MustNewConstMetric(c.memoryTotal, prometheus.GaugeValue, amdTotalMemoryBytes, labels...)
+	}
+}
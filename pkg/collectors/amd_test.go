@@ -0,0 +1,83 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/thediymaker/fake-prometheus/pkg/topology"
+)
+
+func amdNode() topology.NodeConfig {
+	return topology.NodeConfig{
+		Hostname: "g002",
+		GPUs:     topology.GPUConfig{Count: 2, Model: "AMD Instinct MI210", Vendor: "amd"},
+	}
+}
+
+func TestAMDCollectorMemoryScalesOffTotal(t *testing.T) {
+	c := NewAMDCollector(amdNode(), "", nil, nil)
+
+	all := collectAll(c)
+	used := samplesFrom(all, "amd_gpu_memory_used")
+	total := samplesFrom(all, "amd_gpu_memory_total")
+	busy := samplesFrom(all, "amd_gpu_memory_busy_percent")
+	if len(used) != 2 || len(total) != 2 || len(busy) != 2 {
+		t.Fatalf("got %d used, %d total, %d busy samples, want 2 each (one per GPU)", len(used), len(total), len(busy))
+	}
+
+	byGPU := func(samples []*dto.Metric) map[string]*dto.Metric {
+		m := make(map[string]*dto.Metric, len(samples))
+		for _, s := range samples {
+			m[labelValue(s, "gpu")] = s
+		}
+		return m
+	}
+	usedByGPU, totalByGPU, busyByGPU := byGPU(used), byGPU(total), byGPU(busy)
+
+	for _, gpu := range []string{"0", "1"} {
+		gotTotal := totalByGPU[gpu].GetGauge().GetValue()
+		if gotTotal != amdTotalMemoryBytes {
+			t.Errorf("amd_gpu_memory_total[gpu=%s] = %v, want %v", gpu, gotTotal, float64(amdTotalMemoryBytes))
+		}
+		wantUsed := amdTotalMemoryBytes * busyByGPU[gpu].GetGauge().GetValue() / 100
+		if gotUsed := usedByGPU[gpu].GetGauge().GetValue(); gotUsed != wantUsed {
+			t.Errorf("amd_gpu_memory_used[gpu=%s] = %v, want %v (amdTotalMemoryBytes * busy%%)", gpu, gotUsed, wantUsed)
+		}
+	}
+}
+
+func TestAMDCollectorLabelSet(t *testing.T) {
+	c := NewAMDCollector(amdNode(), "", nil, nil)
+
+	for _, s := range samplesForMetric(c, "amd_gpu_temp") {
+		if node := labelValue(s, "node"); node != "g002" {
+			t.Errorf("amd_gpu_temp node label = %q, want g002", node)
+		}
+		if pod := labelValue(s, "pod"); pod != "" {
+			t.Errorf("amd_gpu_temp pod label = %q, want empty with no scheduler", pod)
+		}
+	}
+}
+
+// TestAMDAndDCGMCollectorsCoexistOnOneRegistry exercises main.go's
+// --gpu-vendor=mixed wiring: an AMDCollector and a DCGMCollector, each with
+// their own distinct metric names, must register and scrape together on one
+// registry without collisions.
+func TestAMDAndDCGMCollectorsCoexistOnOneRegistry(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	amd := NewAMDCollector(amdNode(), "", nil, nil)
+	dcgm := NewDCGMCollector(migNode(), "", nil, nil)
+
+	if err := reg.Register(amd); err != nil {
+		t.Fatalf("registering AMDCollector: %v", err)
+	}
+	if err := reg.Register(dcgm); err != nil {
+		t.Fatalf("registering DCGMCollector: %v", err)
+	}
+
+	if _, err := reg.Gather(); err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+// Package collectors holds the fake hardware exporters (IPMI, DCGM, ...).
+// Each exporter implements FakeCollector. main registers the IPMI and GPU
+// collectors into separate prometheus.Registry instances served on separate
+// HTTP paths, so the metrics of one fake device don't leak into another's
+// scrape; collectors sharing one path (DCGM and AMD in mixed mode) share
+// that path's registry.
+package collectors
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// FakeCollector is a prometheus.Collector that knows how to describe itself
+// for registration and logging. New fake exporters should implement this
+// interface and be wired up in main; nothing else needs to change.
+type FakeCollector interface {
+	prometheus.Collector
+
+	// Name identifies the collector, e.g. "ipmi" or "gpu". HTTP paths are
+	// set in main and do not depend on it.
+	Name() string
+}
@@ -0,0 +1,482 @@
+package collectors
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/thediymaker/fake-prometheus/pkg/scenario"
+	"github.com/thediymaker/fake-prometheus/pkg/topology"
+	"github.com/thediymaker/fake-prometheus/pkg/workload"
+)
+
+// gpuTotalMemoryMB is the frame buffer size reported for each simulated GPU
+// (80GB, matching an A100-SXM4-80GB).
+const gpuTotalMemoryMB = 81920
+
+// nvlinkCount is the number of NVLink links reported per GPU (matching an
+// A100-SXM4's NVLink 3 interconnect).
+const nvlinkCount = 12
+
+var gpuCommonLabels = []string{
+	"gpu",
+	"UUID",
+	"device",
+	"modelName",
+	"Hostname",
+	"DCGM_FI_DRIVER_VERSION",
+	// Present on every series for dimensional consistency; empty for GPUs
+	// (or the whole-device rows of a MIG-partitioned GPU) with no MIG
+	// instance of their own.
+	"GPU_I_ID",
+	"GPU_I_PROFILE",
+	// Kubernetes workload attribution, matching the labels
+	// nvidia-dcgm-exporter emits when wired to the k8s device plugin. Empty
+	// for GPUs with no workload currently scheduled on them.
+	"pod",
+	"namespace",
+	"container",
+	"exported_container",
+}
+
+var nvlinkLabels = append(append([]string{}, gpuCommonLabels...), "link")
+
+// migInstance is one MIG partition of a GPU.
+type migInstance struct {
+	id      string
+	profile string
+	// shareOfGPU is roughly this instance's fraction of the full GPU's
+	// compute/memory, used to scale its derived metrics.
+	shareOfGPU float64
+}
+
+// buildMIGInstances groups node's configured MIG partitions by GPU index, so
+// Collect can look up a GPU's instances (if any) by its "gpu" label value. A
+// configured GPU reports both its whole-device rows (GPU_I_ID/GPU_I_PROFILE
+// empty) and one extra row per instance, so dashboards built for mixed
+// MIG/non-MIG A100/H100 fleets have something realistic to query. Nodes with
+// no MIG entries configured report no MIG rows at all.
+func buildMIGInstances(node topology.NodeConfig) map[string][]migInstance {
+	instances := make(map[string][]migInstance)
+	for _, m := range node.GPUs.MIG {
+		gpuNum := fmt.Sprintf("%d", m.GPUIndex)
+		instances[gpuNum] = append(instances[gpuNum], migInstance{
+			id:         fmt.Sprintf("%d", len(instances[gpuNum])),
+			profile:    m.Profile,
+			shareOfGPU: m.ShareOfGPU,
+		})
+	}
+	return instances
+}
+
+// dcgmDriverVersion is the driver version reported for every simulated
+// NVIDIA GPU.
+const dcgmDriverVersion = "560.35.03"
+
+// buildGPUInfo describes node's simulated GPU fleet. UUIDs are derived
+// deterministically from the node's hostname and GPU index, so a node's
+// identity is stable across restarts without anything having to persist it.
+func buildGPUInfo(node topology.NodeConfig) []map[string]string {
+	gpus := make([]map[string]string, node.GPUs.Count)
+	for i := 0; i < node.GPUs.Count; i++ {
+		gpuNum := fmt.Sprintf("%d", i)
+		gpus[i] = map[string]string{
+			"gpu":                    gpuNum,
+			"UUID":                   topology.FakeUUID(fmt.Sprintf("%s-gpu%s", node.Hostname, gpuNum)),
+			"device":                 "nvidia" + gpuNum,
+			"modelName":              node.GPUs.Model,
+			"Hostname":               node.Hostname,
+			"DCGM_FI_DRIVER_VERSION": dcgmDriverVersion,
+		}
+	}
+	return gpus
+}
+
+// metricKind distinguishes gauge-like readings from monotonic counters so
+// DCGMCollector knows which prometheus.ValueType to report at Collect time.
+type metricKind int
+
+const (
+	gaugeMetric metricKind = iota
+	counterMetric
+)
+
+type metricInfo struct {
+	name string
+	help string
+	kind metricKind
+}
+
+var dcgmMetricDefinitions = []metricInfo{
+	{"DCGM_FI_DEV_SM_CLOCK", "SM clock frequency (in MHz).", gaugeMetric},
+	{"DCGM_FI_DEV_MEM_CLOCK", "Memory clock frequency (in MHz).", gaugeMetric},
+	{"DCGM_FI_DEV_MEMORY_TEMP", "Memory temperature (in C).", gaugeMetric},
+	{"DCGM_FI_DEV_GPU_TEMP", "GPU temperature (in C).", gaugeMetric},
+	{"DCGM_FI_DEV_POWER_USAGE", "Power draw (in W).", gaugeMetric},
+	{"DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION", "Total energy consumption since boot (in mJ).", counterMetric},
+	{"DCGM_FI_DEV_GPU_UTIL", "GPU utilization (in %).", gaugeMetric},
+	{"DCGM_FI_DEV_MEM_COPY_UTIL", "Memory utilization (in %).", gaugeMetric},
+	{"DCGM_FI_DEV_ENC_UTIL", "Encoder utilization (in %).", gaugeMetric},
+	{"DCGM_FI_DEV_DEC_UTIL", "Decoder utilization (in %).", gaugeMetric},
+	{"DCGM_FI_DEV_FB_FREE", "Frame buffer memory free (in MB).", gaugeMetric},
+	{"DCGM_FI_DEV_FB_USED", "Frame buffer memory used (in MB).", gaugeMetric},
+	{"DCGM_FI_DEV_PCIE_REPLAY_COUNTER", "Total number of PCIe retries.", counterMetric},
+
+	{"DCGM_FI_DEV_ECC_SBE_VOL_TOTAL", "Total number of single bit volatile ECC errors.", counterMetric},
+	{"DCGM_FI_DEV_ECC_DBE_VOL_TOTAL", "Total number of double bit volatile ECC errors.", counterMetric},
+	{"DCGM_FI_DEV_RETIRED_SBE", "Total number of retired pages due to single bit errors.", gaugeMetric},
+	{"DCGM_FI_DEV_RETIRED_DBE", "Total number of retired pages due to double bit errors.", gaugeMetric},
+	{"DCGM_FI_DEV_XID_ERRORS", "Value of the last XID error encountered, 0 if none.", gaugeMetric},
+	{"DCGM_FI_DEV_ROW_REMAP_FAILURE", "1 if row remapping has failed for this GPU, 0 otherwise.", gaugeMetric},
+
+	{"DCGM_FI_PROF_GR_ENGINE_ACTIVE", "Ratio of time the graphics/compute engine is active.", gaugeMetric},
+	{"DCGM_FI_PROF_PIPE_TENSOR_ACTIVE", "Ratio of time the tensor (HMMA) pipe is active.", gaugeMetric},
+	{"DCGM_FI_PROF_DRAM_ACTIVE", "Ratio of time the device memory interface is active.", gaugeMetric},
+	{"DCGM_FI_PROF_PCIE_TX_BYTES", "PCIe transmit throughput in bytes per second.", gaugeMetric},
+	{"DCGM_FI_PROF_PCIE_RX_BYTES", "PCIe receive throughput in bytes per second.", gaugeMetric},
+}
+
+// DCGMCollector generates fake nvidia-dcgm-exporter-style metrics for a
+// fixed fleet of GPUs at scrape time. Between Collect calls it keeps only
+// what must persist across scrapes: its monotonic counters, the sticky row
+// remap failure flag, and the last temperature and collect time that new
+// readings are derived from.
+type DCGMCollector struct {
+	// mu guards every field below against concurrent Collect calls:
+	// promhttp's handler gathers a registry's collectors from whichever
+	// goroutine is handling a given scrape, and Collect mutates counters
+	// and prevTemp/lastCollect in place, so two simultaneous scrapes of the
+	// same registry must not run this method at once.
+	mu sync.Mutex
+
+	descs          map[string]*prometheus.Desc
+	nvlinkBW       *prometheus.Desc
+	nvlinkCRCError *prometheus.Desc
+
+	// gpuInfo is this node's simulated GPU fleet.
+	gpuInfo []map[string]string
+
+	// migInstances groups this node's configured MIG partitions by GPU
+	// index, populated once at construction and read-only thereafter.
+	migInstances map[string][]migInstance
+
+	// componentPrefix namespaces this node's scenario/workload component ids
+	// (e.g. "g002/") so a multi-node topology's GPUs don't share fault or
+	// workload state with each other. Empty for a single-node deployment.
+	componentPrefix string
+
+	// engine drives each GPU's idle/training/throttled/failed state. If nil,
+	// GPUs fall back to the old stateless random utilization roll.
+	engine *scenario.Engine
+
+	// scheduler attributes a synthetic pod to each GPU. If nil, GPUs report
+	// no workload labels.
+	scheduler *workload.Scheduler
+
+	// energyConsumption, pcieReplays, eccSBE/DBE, retiredDBE and
+	// nvlinkCRCErrors accumulate their counters per GPU between scrapes,
+	// since counters must never go backwards. retiredSBE is reported but
+	// never incremented, so it stays 0. prevTemp holds the last
+	// reported GPU temp so new readings can lag utilization instead of
+	// jumping straight to it.
+	energyConsumption map[string]float64
+	pcieReplays       map[string]float64
+	eccSBE            map[string]float64
+	eccDBE            map[string]float64
+	retiredSBE        map[string]float64
+	retiredDBE        map[string]float64
+	nvlinkCRCErrors   map[string][]float64
+	rowRemapFailed    map[string]bool
+	prevTemp          map[string]float64
+	lastCollect       time.Time
+}
+
+// NewDCGMCollector returns a DCGMCollector for node's configured GPU fleet.
+// componentPrefix namespaces this node's scenario/workload component ids for
+// multi-node topologies; pass "" for a single-node deployment. engine and
+// scheduler may be nil, in which case GPU state and workload labels fall
+// back to plain random sampling and no attribution, as before.
+func NewDCGMCollector(node topology.NodeConfig, componentPrefix string, engine *scenario.Engine, scheduler *workload.Scheduler) *DCGMCollector {
+	gpuInfo := buildGPUInfo(node)
+
+	descs := make(map[string]*prometheus.Desc, len(dcgmMetricDefinitions))
+	for _, m := range dcgmMetricDefinitions {
+		descs[m.name] = prometheus.NewDesc(m.name, m.help, gpuCommonLabels, nil)
+	}
+
+	energy := make(map[string]float64, len(gpuInfo))
+	replays := make(map[string]float64, len(gpuInfo))
+	eccSBE := make(map[string]float64, len(gpuInfo))
+	eccDBE := make(map[string]float64, len(gpuInfo))
+	retiredSBE := make(map[string]float64, len(gpuInfo))
+	retiredDBE := make(map[string]float64, len(gpuInfo))
+	nvlinkCRCErrors := make(map[string][]float64, len(gpuInfo))
+	rowRemapFailed := make(map[string]bool, len(gpuInfo))
+	prevTemp := make(map[string]float64, len(gpuInfo))
+	for _, gpu := range gpuInfo {
+		energy[gpu["gpu"]] = randomInRange(1200000000000, 1800000000000)
+		nvlinkCRCErrors[gpu["gpu"]] = make([]float64, nvlinkCount)
+		prevTemp[gpu["gpu"]] = 35
+	}
+
+	return &DCGMCollector{
+		descs: descs,
+		nvlinkBW: prometheus.NewDesc(
+			"DCGM_FI_DEV_NVLINK_BANDWIDTH_TOTAL", "Total NVLink bandwidth for a link (in MB/s).", nvlinkLabels, nil,
+		),
+		nvlinkCRCError: prometheus.NewDesc(
+			"DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL", "Total number of NVLink FLIT CRC errors for a link.", nvlinkLabels, nil,
+		),
+		gpuInfo:           gpuInfo,
+		migInstances:      buildMIGInstances(node),
+		componentPrefix:   componentPrefix,
+		engine:            engine,
+		scheduler:         scheduler,
+		energyConsumption: energy,
+		pcieReplays:       replays,
+		eccSBE:            eccSBE,
+		eccDBE:            eccDBE,
+		retiredSBE:        retiredSBE,
+		retiredDBE:        retiredDBE,
+		nvlinkCRCErrors:   nvlinkCRCErrors,
+		rowRemapFailed:    rowRemapFailed,
+		prevTemp:          prevTemp,
+		lastCollect:       time.Now(),
+	}
+}
+
+// gpuComponent returns the scenario/workload component id for a GPU, e.g.
+// "gpu0" or, on a namespaced node, "g002/gpu0".
+func (c *DCGMCollector) gpuComponent(gpuNum string) string {
+	return c.componentPrefix + "gpu" + gpuNum
+}
+
+// targetUtilization returns the utilization range a GPU's scenario state
+// implies. isActive mirrors the old random roll for callers with no engine.
+func targetUtilization(state string, isActive bool) float64 {
+	switch state {
+	case "training":
+		return randomInRange(60, 100)
+	case "throttled":
+		return randomInRange(85, 100)
+	case "failed":
+		return 0
+	case "idle":
+		return randomInRange(0, 15)
+	default:
+		if isActive {
+			return randomInRange(60, 100)
+		}
+		return randomInRange(0, 15)
+	}
+}
+
+// Name implements FakeCollector.
+func (c *DCGMCollector) Name() string { return "gpu" }
+
+// Describe implements prometheus.Collector.
+func (c *DCGMCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+	ch <- c.nvlinkBW
+	ch <- c.nvlinkCRCError
+}
+
+// Collect implements prometheus.Collector, generating a full set of fake
+// readings for this scrape.
+func (c *DCGMCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elapsed := time.Since(c.lastCollect).Seconds()
+	c.lastCollect = time.Now()
+
+	for _, gpu := range c.gpuInfo {
+		gpuNum := gpu["gpu"]
+		var wl workload.Workload
+		if c.scheduler != nil {
+			wl = c.scheduler.Assignment(c.gpuComponent(gpuNum))
+		}
+		labels := labelValues(gpu, wl)
+
+		// Randomly determine if GPU is in use (80% chance on every scrape)
+		isActive := rand.Float64() < 0.8
+
+		state := ""
+		if c.engine != nil {
+			state = c.engine.CurrentState(c.gpuComponent(gpuNum))
+		}
+
+		// GPU Utilization (0-100%)
+		gpuUtil := targetUtilization(state, isActive)
+		ch <- c.metric("DCGM_FI_DEV_GPU_UTIL", gpuUtil, labels...)
+
+		// Memory Clock (1593 MHz for A100, slight variations)
+		ch <- c.metric("DCGM_FI_DEV_MEM_CLOCK", randomInRange(1590, 1595), labels...)
+
+		// SM Clock (210-1410 MHz), clamped low for a failed GPU
+		switch {
+		case state == "failed":
+			ch <- c.metric("DCGM_FI_DEV_SM_CLOCK", 0, labels...)
+		case gpuUtil > 50:
+			ch <- c.metric("DCGM_FI_DEV_SM_CLOCK", randomInRange(1380, 1410), labels...)
+		default:
+			ch <- c.metric("DCGM_FI_DEV_SM_CLOCK", randomInRange(210, 300), labels...)
+		}
+
+		// Temperature follows utilization but lags it rather than jumping
+		// straight there, and throttled GPUs run hot.
+		targetTemp := 30.0 + (gpuUtil * 0.5)
+		if state == "throttled" {
+			targetTemp += 20
+		}
+		baseTemp := c.prevTemp[gpuNum]*0.7 + targetTemp*0.3
+		c.prevTemp[gpuNum] = baseTemp
+		ch <- c.metric("DCGM_FI_DEV_GPU_TEMP", baseTemp+randomInRange(-2, 2), labels...)
+		ch <- c.metric("DCGM_FI_DEV_MEMORY_TEMP", baseTemp+randomInRange(-5, 10), labels...)
+
+		// Power usage follows temperature, which in turn follows utilization.
+		basePower := 60 + (380 * (baseTemp - 30) / 0.5 / 100)
+		if state == "failed" {
+			basePower = 0
+		}
+		ch <- c.metric("DCGM_FI_DEV_POWER_USAGE", basePower+randomInRange(-10, 10), labels...)
+
+		// Memory utilization and usage
+		memUtil := 0.0
+		if isActive {
+			memUtil = randomInRange(10, 90)
+		} else {
+			memUtil = randomInRange(0, 5)
+		}
+		ch <- c.metric("DCGM_FI_DEV_MEM_COPY_UTIL", memUtil, labels...)
+
+		usedMem := gpuTotalMemoryMB * memUtil / 100
+		ch <- c.metric("DCGM_FI_DEV_FB_USED", usedMem, labels...)
+		ch <- c.metric("DCGM_FI_DEV_FB_FREE", gpuTotalMemoryMB-usedMem, labels...)
+
+		// Encoder/Decoder (usually 0 for compute cards, but occasionally show small values)
+		ch <- c.metric("DCGM_FI_DEV_ENC_UTIL", randomInRange(0, 1), labels...)
+		ch <- c.metric("DCGM_FI_DEV_DEC_UTIL", randomInRange(0, 1), labels...)
+
+		// Energy consumption increases over time
+		c.energyConsumption[gpuNum] += basePower * elapsed * 1000 // elapsed seconds in millijoules
+		ch <- prometheus.MustNewConstMetric(c.descs["DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION"], prometheus.CounterValue, c.energyConsumption[gpuNum], labels...)
+
+		// PCIe retries (very rare, only increment occasionally)
+		if rand.Float64() < 0.01 { // 1% chance
+			c.pcieReplays[gpuNum]++
+		}
+		ch <- prometheus.MustNewConstMetric(c.descs["DCGM_FI_DEV_PCIE_REPLAY_COUNTER"], prometheus.CounterValue, c.pcieReplays[gpuNum], labels...)
+
+		// PCIe throughput loosely tracks utilization.
+		ch <- c.metric("DCGM_FI_PROF_PCIE_TX_BYTES", randomInRange(1e6, 5e8)*gpuUtil/100, labels...)
+		ch <- c.metric("DCGM_FI_PROF_PCIE_RX_BYTES", randomInRange(1e6, 5e8)*gpuUtil/100, labels...)
+
+		// Profiling ratios track utilization with their own jitter.
+		ch <- c.metric("DCGM_FI_PROF_GR_ENGINE_ACTIVE", gpuUtil/100*randomInRange(0.9, 1.0), labels...)
+		ch <- c.metric("DCGM_FI_PROF_PIPE_TENSOR_ACTIVE", gpuUtil/100*randomInRange(0.5, 0.9), labels...)
+		ch <- c.metric("DCGM_FI_PROF_DRAM_ACTIVE", memUtil/100*randomInRange(0.8, 1.0), labels...)
+
+		// NVLink bandwidth and CRC errors, one row per link.
+		for link := 0; link < nvlinkCount; link++ {
+			linkLabels := append(append([]string{}, labels...), fmt.Sprintf("%d", link))
+			bw := randomInRange(0, 25000) * gpuUtil / 100
+			ch <- prometheus.MustNewConstMetric(c.nvlinkBW, prometheus.GaugeValue, bw, linkLabels...)
+			if rand.Float64() < 0.001 { // 0.1% chance per link per scrape
+				c.nvlinkCRCErrors[gpuNum][link]++
+			}
+			ch <- prometheus.MustNewConstMetric(c.nvlinkCRCError, prometheus.CounterValue, c.nvlinkCRCErrors[gpuNum][link], linkLabels...)
+		}
+
+		// ECC and retired-page errors are rare, and a failed GPU's XID error
+		// and row remap failure are driven by its scenario state so alerting
+		// rules can be tested against them on demand via /admin/inject.
+		if rand.Float64() < 0.001 {
+			c.eccSBE[gpuNum]++
+		}
+		if rand.Float64() < 0.0002 {
+			c.eccDBE[gpuNum]++
+			c.retiredDBE[gpuNum]++
+		}
+		ch <- prometheus.MustNewConstMetric(c.descs["DCGM_FI_DEV_ECC_SBE_VOL_TOTAL"], prometheus.CounterValue, c.eccSBE[gpuNum], labels...)
+		ch <- prometheus.MustNewConstMetric(c.descs["DCGM_FI_DEV_ECC_DBE_VOL_TOTAL"], prometheus.CounterValue, c.eccDBE[gpuNum], labels...)
+		ch <- c.metric("DCGM_FI_DEV_RETIRED_SBE", c.retiredSBE[gpuNum], labels...)
+		ch <- c.metric("DCGM_FI_DEV_RETIRED_DBE", c.retiredDBE[gpuNum], labels...)
+
+		xidError := 0.0
+		if state == "failed" {
+			// Both XID and row remap failure are driven directly by the
+			// scenario state (rather than gated behind the random ECC
+			// rolls above), so a forced "failed" injection via
+			// /admin/inject reliably produces both without waiting on
+			// random chance.
+			xidError = 79 // "GPU has fallen off the bus", a classic fatal XID
+			c.rowRemapFailed[gpuNum] = true
+		}
+		ch <- c.metric("DCGM_FI_DEV_XID_ERRORS", xidError, labels...)
+		rowRemap := 0.0
+		if c.rowRemapFailed[gpuNum] {
+			rowRemap = 1
+		}
+		ch <- c.metric("DCGM_FI_DEV_ROW_REMAP_FAILURE", rowRemap, labels...)
+
+		// MIG instances get their own row per partitioned metric, scaled by
+		// their share of the physical GPU.
+		for _, inst := range c.migInstances[gpuNum] {
+			instLabels := instanceLabelValues(gpu, inst, wl)
+			instUtil := gpuUtil * randomInRange(0.7, 1.1)
+			if instUtil > 100 {
+				instUtil = 100
+			}
+			ch <- prometheus.MustNewConstMetric(c.descs["DCGM_FI_DEV_GPU_UTIL"], prometheus.GaugeValue, instUtil, instLabels...)
+			instMem := gpuTotalMemoryMB * inst.shareOfGPU * randomInRange(0.1, 0.9)
+			ch <- prometheus.MustNewConstMetric(c.descs["DCGM_FI_DEV_FB_USED"], prometheus.GaugeValue, instMem, instLabels...)
+			ch <- prometheus.MustNewConstMetric(c.descs["DCGM_FI_DEV_FB_FREE"], prometheus.GaugeValue, gpuTotalMemoryMB*inst.shareOfGPU-instMem, instLabels...)
+			ch <- prometheus.MustNewConstMetric(c.descs["DCGM_FI_PROF_GR_ENGINE_ACTIVE"], prometheus.GaugeValue, instUtil/100*randomInRange(0.9, 1.0), instLabels...)
+		}
+	}
+}
+
+// metric builds a gauge sample for the named metric using this GPU's label
+// values, in the fixed order of gpuCommonLabels.
+func (c *DCGMCollector) metric(name string, value float64, labelValues ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(c.descs[name], prometheus.GaugeValue, value, labelValues...)
+}
+
+// labelValues returns a GPU's whole-device label values in gpuCommonLabels
+// order, with GPU_I_ID/GPU_I_PROFILE left blank.
+func labelValues(gpu map[string]string, wl workload.Workload) []string {
+	return instanceLabelValues(gpu, migInstance{}, wl)
+}
+
+// instanceLabelValues returns a GPU's label values in gpuCommonLabels order,
+// filling GPU_I_ID/GPU_I_PROFILE from inst (zero value for the whole-device
+// row) and the pod/namespace/container labels from wl (zero value for an
+// idle GPU).
+func instanceLabelValues(gpu map[string]string, inst migInstance, wl workload.Workload) []string {
+	values := make([]string, len(gpuCommonLabels))
+	for i, label := range gpuCommonLabels {
+		switch label {
+		case "GPU_I_ID":
+			values[i] = inst.id
+		case "GPU_I_PROFILE":
+			values[i] = inst.profile
+		case "pod":
+			values[i] = wl.Pod
+		case "namespace":
+			values[i] = wl.Namespace
+		case "container":
+			values[i] = wl.Container
+		case "exported_container":
+			values[i] = wl.ExportedContainer
+		default:
+			values[i] = gpu[label]
+		}
+	}
+	return values
+}
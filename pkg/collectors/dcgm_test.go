@@ -0,0 +1,185 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/thediymaker/fake-prometheus/pkg/scenario"
+	"github.com/thediymaker/fake-prometheus/pkg/topology"
+)
+
+func migNode() topology.NodeConfig {
+	return topology.NodeConfig{
+		Hostname: "g001",
+		GPUs: topology.GPUConfig{
+			Count:  2,
+			Model:  "NVIDIA A100-SXM4-80GB",
+			Vendor: "nvidia",
+			MIG: []topology.MIGInstanceConfig{
+				{GPUIndex: 0, Profile: "1g.10gb", ShareOfGPU: 0.125},
+			},
+		},
+	}
+}
+
+// collectAll scrapes c and returns every sample as a dto.Metric, grouped by
+// the string form of its Desc, each group in collection order.
+func collectAll(c prometheus.Collector) map[string][]*dto.Metric {
+	ch := make(chan prometheus.Metric, 4096)
+	c.Collect(ch)
+	close(ch)
+
+	samples := make(map[string][]*dto.Metric)
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			panic(err)
+		}
+		desc := metric.Desc().String()
+		samples[desc] = append(samples[desc], &m)
+	}
+	return samples
+}
+
+func samplesForMetric(c prometheus.Collector, name string) []*dto.Metric {
+	return samplesFrom(collectAll(c), name)
+}
+
+// samplesFrom finds name's samples within an already-collected set, so
+// multiple metrics can be read from one scrape instead of scraping once per
+// metric (which would re-roll every randomized value in between).
+func samplesFrom(all map[string][]*dto.Metric, name string) []*dto.Metric {
+	for desc, samples := range all {
+		if strings.Contains(desc, `fqName: "`+name+`"`) {
+			return samples
+		}
+	}
+	return nil
+}
+
+func TestDCGMCollectorMIGRowsAndWholeDeviceRows(t *testing.T) {
+	c := NewDCGMCollector(migNode(), "", nil, nil)
+
+	samples := samplesForMetric(c, "DCGM_FI_DEV_GPU_UTIL")
+	if len(samples) == 0 {
+		t.Fatal("no DCGM_FI_DEV_GPU_UTIL samples collected")
+	}
+
+	var sawMIGRow, sawBlankGPU0, sawBlankGPU1 bool
+	for _, s := range samples {
+		gpu := labelValue(s, "gpu")
+		migID := labelValue(s, "GPU_I_ID")
+		migProfile := labelValue(s, "GPU_I_PROFILE")
+		switch {
+		case gpu == "0" && migID != "":
+			sawMIGRow = true
+			if migProfile != "1g.10gb" {
+				t.Errorf("MIG row GPU_I_PROFILE = %q, want 1g.10gb", migProfile)
+			}
+		case gpu == "0" && migID == "":
+			sawBlankGPU0 = true
+		case gpu == "1" && migID == "":
+			sawBlankGPU1 = true
+			if migProfile != "" {
+				t.Errorf("gpu1 whole-device row GPU_I_PROFILE = %q, want blank (no MIG configured)", migProfile)
+			}
+		case gpu == "1" && migID != "":
+			t.Errorf("gpu1 has a MIG row but no MIG instances are configured for it: GPU_I_ID=%q", migID)
+		}
+	}
+	if !sawMIGRow {
+		t.Error("never saw gpu0's MIG instance row")
+	}
+	if !sawBlankGPU0 {
+		t.Error("never saw gpu0's whole-device row (blank GPU_I_ID)")
+	}
+	if !sawBlankGPU1 {
+		t.Error("never saw gpu1's whole-device row")
+	}
+}
+
+func counterValues(samples []*dto.Metric) map[string]float64 {
+	values := make(map[string]float64, len(samples))
+	for _, s := range samples {
+		values[labelValue(s, "gpu")] = s.GetCounter().GetValue()
+	}
+	return values
+}
+
+func TestDCGMCollectorCountersAreMonotonic(t *testing.T) {
+	c := NewDCGMCollector(migNode(), "", nil, nil)
+
+	counterMetrics := []string{
+		"DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION",
+		"DCGM_FI_DEV_PCIE_REPLAY_COUNTER",
+		"DCGM_FI_DEV_ECC_SBE_VOL_TOTAL",
+		"DCGM_FI_DEV_ECC_DBE_VOL_TOTAL",
+		"DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL",
+	}
+
+	before := make(map[string]map[string]float64, len(counterMetrics))
+	for _, name := range counterMetrics {
+		before[name] = counterValues(samplesForMetric(c, name))
+	}
+
+	time.Sleep(time.Millisecond)
+
+	for _, name := range counterMetrics {
+		after := counterValues(samplesForMetric(c, name))
+		for gpu, prev := range before[name] {
+			if got := after[gpu]; got < prev {
+				t.Errorf("%s[gpu=%s] went from %v to %v across scrapes, want non-decreasing", name, gpu, prev, got)
+			}
+		}
+	}
+}
+
+func TestDCGMCollectorFailedStateDrivesXIDAndRowRemap(t *testing.T) {
+	cfg := scenario.Config{
+		Components: map[string]scenario.ComponentDef{
+			"gpu0": {
+				Initial: "idle",
+				States: map[string]scenario.StateDef{
+					"idle":   {Transitions: map[string]float64{}},
+					"failed": {Transitions: map[string]float64{}},
+				},
+			},
+		},
+	}
+	engine := scenario.NewEngine(cfg)
+	if err := engine.Inject("gpu0", "failed", time.Hour); err != nil {
+		t.Fatalf("Inject: %v", err)
+	}
+
+	c := NewDCGMCollector(migNode(), "", engine, nil)
+
+	xidSamples := samplesForMetric(c, "DCGM_FI_DEV_XID_ERRORS")
+	rowRemapSamples := samplesForMetric(c, "DCGM_FI_DEV_ROW_REMAP_FAILURE")
+
+	foundXID, foundRemap := false, false
+	for _, s := range xidSamples {
+		if labelValue(s, "gpu") != "0" {
+			continue
+		}
+		foundXID = true
+		if got := s.GetGauge().GetValue(); got != 79 {
+			t.Errorf("DCGM_FI_DEV_XID_ERRORS[gpu0] = %v, want 79 while failed", got)
+		}
+	}
+	for _, s := range rowRemapSamples {
+		if labelValue(s, "gpu") != "0" {
+			continue
+		}
+		foundRemap = true
+		if got := s.GetGauge().GetValue(); got != 1 {
+			t.Errorf("DCGM_FI_DEV_ROW_REMAP_FAILURE[gpu0] = %v, want 1 while failed", got)
+		}
+	}
+	if !foundXID || !foundRemap {
+		t.Fatal("never saw gpu0's XID/row-remap samples")
+	}
+}
@@ -0,0 +1,321 @@
+package collectors
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/thediymaker/fake-prometheus/pkg/scenario"
+	"github.com/thediymaker/fake-prometheus/pkg/topology"
+	"github.com/thediymaker/fake-prometheus/pkg/workload"
+)
+
+// powerLabels extends the usual id/name sensor labels with Kubernetes
+// workload attribution, so ipmi_power_watts can be joined against the
+// dominant job running on the node the way kube-state-metrics dashboards
+// expect.
+var powerLabels = []string{"id", "name", "pod", "namespace", "container", "exported_container"}
+
+// IPMICollector generates fake ipmi_exporter-style metrics for a single BMC
+// at scrape time. Values are computed fresh inside Collect, so every scrape
+// reflects the exact wall-clock moment instead of whatever a background
+// ticker last wrote.
+type IPMICollector struct {
+	// mu guards Collect against running concurrently with itself: promhttp's
+	// handler gathers a registry's collectors from whichever goroutine is
+	// handling a given scrape, and every field below is read-only once
+	// constructed, but future state (à la DCGMCollector/AMDCollector) is
+	// likely to land here, so the lock is held for the whole call up front.
+	mu sync.Mutex
+
+	nodeName string
+
+	// bmcAddress is this node's simulated BMC network address, reported on
+	// bmcInfo.
+	bmcAddress string
+
+	// fanPairs is the number of A/B fan pairs this node reports.
+	fanPairs int
+
+	// componentPrefix namespaces this node's scenario/workload component ids
+	// (e.g. "g002/") for multi-node topologies. Empty for a single-node
+	// deployment.
+	componentPrefix string
+
+	// engine drives the PSU's nominal/overcurrent state. If nil, the PSU
+	// falls back to the old stateless random current draw.
+	engine *scenario.Engine
+
+	// scheduler attributes a synthetic pod to the node's power draw. If
+	// nil, power metrics report no workload labels.
+	scheduler *workload.Scheduler
+
+	nodeUnameInfo  *prometheus.Desc
+	bmcInfo        *prometheus.Desc
+	fanSpeedRPM    *prometheus.Desc
+	fanSpeedState  *prometheus.Desc
+	temperature    *prometheus.Desc
+	temperatureSt  *prometheus.Desc
+	powerWatts     *prometheus.Desc
+	powerState     *prometheus.Desc
+	currentAmperes *prometheus.Desc
+	currentState   *prometheus.Desc
+	voltageVolts   *prometheus.Desc
+	voltageState   *prometheus.Desc
+	selFreeSpace   *prometheus.Desc
+	selLogsCount   *prometheus.Desc
+	scrapeDuration *prometheus.Desc
+	up             *prometheus.Desc
+}
+
+// NewIPMICollector returns an IPMICollector reporting as node. componentPrefix
+// namespaces this node's scenario/workload component ids for multi-node
+// topologies; pass "" for a single-node deployment. engine and scheduler may
+// be nil, in which case PSU state falls back to plain random sampling and
+// power metrics report no workload attribution.
+func NewIPMICollector(node topology.NodeConfig, componentPrefix string, engine *scenario.Engine, scheduler *workload.Scheduler) *IPMICollector {
+	idName := []string{"id", "name"}
+	return &IPMICollector{
+		nodeName:        node.Hostname,
+		bmcAddress:      node.BMCAddress,
+		fanPairs:        node.Fans.Pairs,
+		componentPrefix: componentPrefix,
+		engine:          engine,
+		scheduler:       scheduler,
+
+		nodeUnameInfo: prometheus.NewDesc(
+			"node_uname_info",
+			"Labeled system information as provided by the uname system call.",
+			[]string{"nodename"}, nil,
+		),
+		bmcInfo: prometheus.NewDesc(
+			"ipmi_bmc_info",
+			"Labeled BMC network address for this node.",
+			[]string{"address"}, nil,
+		),
+		fanSpeedRPM: prometheus.NewDesc(
+			"ipmi_fan_speed_rpm", "Fan speed in rotations per minute.", idName, nil,
+		),
+		fanSpeedState: prometheus.NewDesc(
+			"ipmi_fan_speed_state", "Reported state of a fan speed sensor (0=nominal, 1=warning, 2=critical).", idName, nil,
+		),
+		temperature: prometheus.NewDesc(
+			"ipmi_temperature_celsius", "Temperature reading in degree Celsius.", idName, nil,
+		),
+		temperatureSt: prometheus.NewDesc(
+			"ipmi_temperature_state", "Reported state of a temperature sensor (0=nominal, 1=warning, 2=critical).", idName, nil,
+		),
+		powerWatts: prometheus.NewDesc(
+			"ipmi_power_watts", "Power reading in Watts.", powerLabels, nil,
+		),
+		powerState: prometheus.NewDesc(
+			"ipmi_power_state", "Reported state of a power sensor (0=nominal, 1=warning, 2=critical).", powerLabels, nil,
+		),
+		currentAmperes: prometheus.NewDesc(
+			"ipmi_current_amperes", "Current reading in Amperes.", idName, nil,
+		),
+		currentState: prometheus.NewDesc(
+			"ipmi_current_state", "Reported state of a current sensor (0=nominal, 1=warning, 2=critical).", idName, nil,
+		),
+		voltageVolts: prometheus.NewDesc(
+			"ipmi_voltage_volts", "Voltage reading in Volts.", idName, nil,
+		),
+		voltageState: prometheus.NewDesc(
+			"ipmi_voltage_state", "Reported state of a voltage sensor (0=nominal, 1=warning, 2=critical).", idName, nil,
+		),
+		selFreeSpace: prometheus.NewDesc(
+			"ipmi_sel_free_space_bytes", "Current free space remaining for new SEL entries.", nil, nil,
+		),
+		selLogsCount: prometheus.NewDesc(
+			"ipmi_sel_logs_count", "Current number of log entries in the SEL.", nil, nil,
+		),
+		scrapeDuration: prometheus.NewDesc(
+			"ipmi_scrape_duration_seconds", "Returns how long the scrape took to complete in seconds.", nil, nil,
+		),
+		up: prometheus.NewDesc(
+			"ipmi_up", "'1' if a scrape of the IPMI device was successful, '0' otherwise.", []string{"collector"}, nil,
+		),
+	}
+}
+
+// Name implements FakeCollector.
+func (c *IPMICollector) Name() string { return "ipmi" }
+
+// Describe implements prometheus.Collector.
+func (c *IPMICollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.nodeUnameInfo
+	ch <- c.bmcInfo
+	ch <- c.fanSpeedRPM
+	ch <- c.fanSpeedState
+	ch <- c.temperature
+	ch <- c.temperatureSt
+	ch <- c.powerWatts
+	ch <- c.powerState
+	ch <- c.currentAmperes
+	ch <- c.currentState
+	ch <- c.voltageVolts
+	ch <- c.voltageState
+	ch <- c.selFreeSpace
+	ch <- c.selLogsCount
+	ch <- c.scrapeDuration
+	ch <- c.up
+}
+
+// Collect implements prometheus.Collector, generating a full set of fake
+// readings for this scrape.
+func (c *IPMICollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	start := time.Now()
+
+	ch <- prometheus.MustNewConstMetric(c.nodeUnameInfo, prometheus.GaugeValue, 1, c.nodeName)
+	ch <- prometheus.MustNewConstMetric(c.bmcInfo, prometheus.GaugeValue, 1, c.bmcAddress)
+
+	// Exhaust temp is rolled before the fans so a hot exhaust can ramp fan
+	// speed up, the way a real BMC's fan curve would react.
+	exhaustTemp := 31 + rand.Float64()*3
+
+	// Fan speed metrics (fanPairs A/B pairs, 18 by default)
+	fanRampup := 1.0
+	if exhaustTemp > 33 {
+		fanRampup = 1 + (exhaustTemp-33)*0.15
+	}
+	for i := 1; i <= c.fanPairs; i++ {
+		idA := fmt.Sprintf("%d", i+3)            // IDs 4-(3+fanPairs) for A fans
+		idB := fmt.Sprintf("%d", i+3+c.fanPairs) // IDs after the A fans for B fans
+
+		fanState := ""
+		if c.engine != nil {
+			fanState = c.engine.CurrentState(fmt.Sprintf("%sfan%d", c.componentPrefix, i))
+		}
+
+		rpmA, stateA := fanReading(5880+rand.Float64()*240, fanRampup, fanState)
+		ch <- prometheus.MustNewConstMetric(c.fanSpeedRPM, prometheus.GaugeValue, rpmA, idA, fmt.Sprintf("Fan%dA", i))
+		ch <- prometheus.MustNewConstMetric(c.fanSpeedState, prometheus.GaugeValue, stateA, idA, fmt.Sprintf("Fan%dA", i))
+
+		rpmB, stateB := fanReading(5040+rand.Float64()*480, fanRampup, fanState)
+		ch <- prometheus.MustNewConstMetric(c.fanSpeedRPM, prometheus.GaugeValue, rpmB, idB, fmt.Sprintf("Fan%dB", i))
+		ch <- prometheus.MustNewConstMetric(c.fanSpeedState, prometheus.GaugeValue, stateB, idB, fmt.Sprintf("Fan%dB", i))
+	}
+
+	// Temperature sensors
+	temps := []struct{ id, name string }{
+		{"1", "Temp"},
+		{"2", "Temp"},
+		{"3", "Inlet Temp"},
+		{"171", "GPU21 Temp"},
+		{"172", "GPU22 Temp"},
+		{"173", "GPU23 Temp"},
+		{"174", "GPU24 Temp"},
+		{"180", "Exhaust Temp"},
+	}
+	for _, temp := range temps {
+		var value float64
+		switch temp.name {
+		case "Inlet Temp":
+			value = 21 + rand.Float64()*2
+		case "Exhaust Temp":
+			value = exhaustTemp
+		case "Temp":
+			value = 54 + rand.Float64()*3
+		default: // GPU temps
+			value = 39 + rand.Float64()*2
+		}
+		ch <- prometheus.MustNewConstMetric(c.temperature, prometheus.GaugeValue, value, temp.id, temp.name)
+		ch <- prometheus.MustNewConstMetric(c.temperatureSt, prometheus.GaugeValue, 0, temp.id, temp.name)
+	}
+
+	// Power consumption (around 1160W, spiking under a PSU overcurrent state)
+	psuState := ""
+	if c.engine != nil {
+		psuState = c.engine.CurrentState(c.componentPrefix + "psu")
+	}
+	powerValue, powerSeverity := 1160+randomInRange(-20, 20), 0.0
+	currentMultiplier := 1.0
+	if psuState == "overcurrent" {
+		powerValue = 1160 + randomInRange(200, 400)
+		powerSeverity = 2
+		currentMultiplier = 1.5
+	}
+	var wl workload.Workload
+	if c.scheduler != nil {
+		wl = c.scheduler.Assignment(c.componentPrefix + "ipmi-power")
+	}
+	ch <- prometheus.MustNewConstMetric(c.powerWatts, prometheus.GaugeValue, powerValue, "91", "Pwr Consumption", wl.Pod, wl.Namespace, wl.Container, wl.ExportedContainer)
+	ch <- prometheus.MustNewConstMetric(c.powerState, prometheus.GaugeValue, powerSeverity, "91", "Pwr Consumption", wl.Pod, wl.Namespace, wl.Container, wl.ExportedContainer)
+
+	// Current sensors
+	currents := []struct{ id, name string }{
+		{"81", "Current 1"},
+		{"82", "Current 2"},
+		{"251", "Current 3"},
+		{"252", "Current 4"},
+	}
+	for _, current := range currents {
+		baseValue := 1.6
+		if current.name == "Current 2" {
+			baseValue = 0.2
+		}
+		ch <- prometheus.MustNewConstMetric(c.currentAmperes, prometheus.GaugeValue, baseValue*currentMultiplier+randomInRange(-0.05, 0.05), current.id, current.name)
+		ch <- prometheus.MustNewConstMetric(c.currentState, prometheus.GaugeValue, powerSeverity, current.id, current.name)
+	}
+
+	// Voltage sensors
+	voltages := []struct{ id, name string }{
+		{"303", "VCORE VR"},
+		{"304", "VCORE VR"},
+		{"305", "MEMABCD VR"},
+		{"306", "MEMEFGH VR"},
+		{"307", "MEMABCD VR"},
+		{"308", "MEMEFGH VR"},
+		{"83", "Voltage 1"},
+		{"84", "Voltage 2"},
+		{"253", "Voltage 3"},
+		{"254", "Voltage 4"},
+	}
+	for _, voltage := range voltages {
+		var value float64
+		switch {
+		case voltage.name == "VCORE VR":
+			value = randomInRange(1.18, 1.20)
+		case voltage.name == "MEMABCD VR" || voltage.name == "MEMEFGH VR":
+			value = randomInRange(1.21, 1.22)
+		default: // Main voltages
+			value = randomInRange(238, 242)
+		}
+		ch <- prometheus.MustNewConstMetric(c.voltageVolts, prometheus.GaugeValue, value, voltage.id, voltage.name)
+		ch <- prometheus.MustNewConstMetric(c.voltageState, prometheus.GaugeValue, 0, voltage.id, voltage.name)
+	}
+
+	// SEL metrics
+	ch <- prometheus.MustNewConstMetric(c.selFreeSpace, prometheus.GaugeValue, 15632)
+	ch <- prometheus.MustNewConstMetric(c.selLogsCount, prometheus.GaugeValue, 47)
+
+	// IPMI up status
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1, "ipmi")
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1, "sel")
+
+	// Scrape duration, derived from the time it took to build this Collect call.
+	ch <- prometheus.MustNewConstMetric(c.scrapeDuration, prometheus.GaugeValue, time.Since(start).Seconds())
+}
+
+func randomInRange(min, max float64) float64 {
+	return min + rand.Float64()*(max-min)
+}
+
+// fanReading applies a fan's scenario state and the current exhaust-driven
+// rampup to a base RPM, returning the reported RPM and severity state.
+func fanReading(baseRPM, rampup float64, state string) (rpm, severity float64) {
+	switch state {
+	case "failed":
+		return randomInRange(0, 200), 2
+	case "degraded":
+		return baseRPM * 0.5, 1
+	default:
+		return baseRPM * rampup, 0
+	}
+}
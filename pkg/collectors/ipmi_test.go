@@ -0,0 +1,83 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/thediymaker/fake-prometheus/pkg/topology"
+)
+
+func testNode() topology.NodeConfig {
+	return topology.NodeConfig{
+		Hostname:   "g001",
+		BMCAddress: "10.0.0.101",
+		Fans:       topology.FanConfig{Pairs: 2},
+		GPUs:       topology.GPUConfig{Count: 2, Model: "NVIDIA A100-SXM4-80GB", Vendor: "nvidia"},
+	}
+}
+
+func TestIPMICollectorScrapesWithoutPanicking(t *testing.T) {
+	c := NewIPMICollector(testNode(), "", nil, nil)
+	if n := testutil.CollectAndCount(c); n == 0 {
+		t.Fatal("CollectAndCount returned 0 metrics, want at least node_uname_info and friends")
+	}
+}
+
+func TestIPMICollectorInfoLabels(t *testing.T) {
+	c := NewIPMICollector(testNode(), "", nil, nil)
+
+	expected := `
+# HELP ipmi_bmc_info Labeled BMC network address for this node.
+# TYPE ipmi_bmc_info gauge
+ipmi_bmc_info{address="10.0.0.101"} 1
+# HELP node_uname_info Labeled system information as provided by the uname system call.
+# TYPE node_uname_info gauge
+node_uname_info{nodename="g001"} 1
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "ipmi_bmc_info", "node_uname_info"); err != nil {
+		t.Errorf("unexpected info metrics:\n%s", err)
+	}
+}
+
+// labelValue finds label's value on m, or "" if m has no such label.
+func labelValue(m *dto.Metric, label string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == label {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+// TestIPMICollectorNilEngineAndScheduler exercises the fallback path: with
+// no engine or scheduler, Collect must not panic on the nil dereferences,
+// and power metrics must report no workload attribution.
+func TestIPMICollectorNilEngineAndScheduler(t *testing.T) {
+	c := NewIPMICollector(testNode(), "", nil, nil)
+
+	ch := make(chan prometheus.Metric, 256)
+	c.Collect(ch)
+	close(ch)
+
+	found := false
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if labelValue(&m, "name") != "Pwr Consumption" {
+			continue
+		}
+		found = true
+		if pod := labelValue(&m, "pod"); pod != "" {
+			t.Errorf("ipmi_power_watts pod label = %q, want empty with no scheduler", pod)
+		}
+	}
+	if !found {
+		t.Fatal("never saw an ipmi_power_watts/ipmi_power_state sample")
+	}
+}
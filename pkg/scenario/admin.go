@@ -0,0 +1,44 @@
+package scenario
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// injectRequest is the POST /admin/inject request body: force component
+// into state for DurationSeconds.
+type injectRequest struct {
+	Component       string  `json:"component"`
+	State           string  `json:"state"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// AdminHandler returns the handler for POST /admin/inject, letting callers
+// force a component into a specific state for a fixed duration without
+// waiting on random chance to produce it.
+func AdminHandler(engine *Engine) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req injectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.DurationSeconds <= 0 {
+			http.Error(w, "duration_seconds must be positive", http.StatusBadRequest)
+			return
+		}
+
+		duration := time.Duration(req.DurationSeconds * float64(time.Second))
+		if err := engine.Inject(req.Component, req.State, duration); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,115 @@
+package scenario
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// override records a state forced via Inject, which pins a component's
+// state until it expires regardless of what the state machine would do.
+type override struct {
+	state   string
+	expires time.Time
+}
+
+// Engine drives every component in a Config through its state machine and
+// answers CurrentState queries from collectors. It is safe for concurrent
+// use: Tick typically runs on its own goroutine while Collect calls read
+// state from scrape goroutines.
+type Engine struct {
+	mu        sync.Mutex
+	config    Config
+	current   map[string]string
+	overrides map[string]override
+
+	// warnedUnknown tracks components CurrentState has already warned
+	// about, so a collector scraping every second doesn't spam the log.
+	warnedUnknown map[string]bool
+}
+
+// NewEngine builds an Engine with every component starting in its
+// configured initial state.
+func NewEngine(config Config) *Engine {
+	current := make(map[string]string, len(config.Components))
+	for name, def := range config.Components {
+		current[name] = def.Initial
+	}
+	return &Engine{
+		config:        config,
+		current:       current,
+		overrides:     make(map[string]override),
+		warnedUnknown: make(map[string]bool),
+	}
+}
+
+// Tick advances every component one step: each outgoing transition's
+// probability is rolled independently, and the first one that hits wins.
+// Components with a live Inject override are left alone until it expires.
+func (e *Engine) Tick() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	for name, def := range e.config.Components {
+		if o, ok := e.overrides[name]; ok {
+			if now.Before(o.expires) {
+				continue
+			}
+			delete(e.overrides, name)
+		}
+
+		state, ok := def.States[e.current[name]]
+		if !ok {
+			continue
+		}
+		for target, prob := range state.Transitions {
+			if rand.Float64() < prob {
+				e.current[name] = target
+				break
+			}
+		}
+	}
+}
+
+// CurrentState returns component's current state name, or "" if the engine
+// has no such component. A component with no matching config entry logs a
+// one-time warning, since it will silently sit in its zero state forever —
+// usually a sign the scenario file's component ids don't match what's
+// scraping it (e.g. a multi-node --topology namespacing ids the --scenario
+// file doesn't).
+func (e *Engine) CurrentState(component string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if o, ok := e.overrides[component]; ok && time.Now().Before(o.expires) {
+		return o.state
+	}
+	state, ok := e.current[component]
+	if !ok && !e.warnedUnknown[component] {
+		log.Printf("scenario: component %q has no entry in the scenario config; it will never leave its default state", component)
+		e.warnedUnknown[component] = true
+	}
+	return state
+}
+
+// Inject forces component into state for the given duration, overriding its
+// natural transitions. It's meant for exercising alerting rules without
+// waiting on random chance, e.g. via the /admin/inject HTTP endpoint.
+func (e *Engine) Inject(component, state string, duration time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	def, ok := e.config.Components[component]
+	if !ok {
+		return fmt.Errorf("scenario: unknown component %q", component)
+	}
+	if _, ok := def.States[state]; !ok {
+		return fmt.Errorf("scenario: component %q has no state %q", component, state)
+	}
+
+	e.overrides[component] = override{state: state, expires: time.Now().Add(duration)}
+	return nil
+}
@@ -0,0 +1,137 @@
+package scenario
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func fixtureConfig() Config {
+	return Config{
+		Components: map[string]ComponentDef{
+			"gpu0": {
+				Initial: "idle",
+				States: map[string]StateDef{
+					"idle":     {Transitions: map[string]float64{"training": 1}},
+					"training": {Transitions: map[string]float64{"failed": 1}},
+					"failed":   {},
+				},
+			},
+		},
+	}
+}
+
+func TestEngineInitialState(t *testing.T) {
+	e := NewEngine(fixtureConfig())
+	if got := e.CurrentState("gpu0"); got != "idle" {
+		t.Errorf("CurrentState(gpu0) = %q, want idle", got)
+	}
+}
+
+func TestEngineUnknownComponent(t *testing.T) {
+	e := NewEngine(fixtureConfig())
+	if got := e.CurrentState("gpu99"); got != "" {
+		t.Errorf("CurrentState(gpu99) = %q, want empty", got)
+	}
+	// Calling it again must not panic or otherwise misbehave once it's
+	// already been warned about.
+	if got := e.CurrentState("gpu99"); got != "" {
+		t.Errorf("CurrentState(gpu99) second call = %q, want empty", got)
+	}
+}
+
+func TestEngineTickFollowsTransitions(t *testing.T) {
+	e := NewEngine(fixtureConfig())
+	e.Tick() // idle -> training (probability 1)
+	if got := e.CurrentState("gpu0"); got != "training" {
+		t.Fatalf("after one tick, CurrentState(gpu0) = %q, want training", got)
+	}
+	e.Tick() // training -> failed (probability 1)
+	if got := e.CurrentState("gpu0"); got != "failed" {
+		t.Fatalf("after two ticks, CurrentState(gpu0) = %q, want failed", got)
+	}
+	e.Tick() // failed has no transitions, stays put
+	if got := e.CurrentState("gpu0"); got != "failed" {
+		t.Fatalf("after three ticks, CurrentState(gpu0) = %q, want failed", got)
+	}
+}
+
+func TestEngineInject(t *testing.T) {
+	e := NewEngine(fixtureConfig())
+	if err := e.Inject("gpu0", "failed", time.Minute); err != nil {
+		t.Fatalf("Inject: %v", err)
+	}
+	if got := e.CurrentState("gpu0"); got != "failed" {
+		t.Fatalf("CurrentState(gpu0) after inject = %q, want failed", got)
+	}
+	// An injected state overrides the natural transition entirely.
+	e.Tick()
+	if got := e.CurrentState("gpu0"); got != "failed" {
+		t.Fatalf("CurrentState(gpu0) after inject+tick = %q, want failed", got)
+	}
+}
+
+func TestEngineInjectExpires(t *testing.T) {
+	e := NewEngine(fixtureConfig())
+	if err := e.Inject("gpu0", "training", time.Millisecond); err != nil {
+		t.Fatalf("Inject: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if got := e.CurrentState("gpu0"); got != "idle" {
+		t.Fatalf("CurrentState(gpu0) after inject expiry = %q, want idle", got)
+	}
+}
+
+func TestEngineInjectUnknownComponentOrState(t *testing.T) {
+	e := NewEngine(fixtureConfig())
+	if err := e.Inject("nope", "idle", time.Minute); err == nil {
+		t.Error("Inject with unknown component: want error, got nil")
+	}
+	if err := e.Inject("gpu0", "nope", time.Minute); err == nil {
+		t.Error("Inject with unknown state: want error, got nil")
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "scenario.yaml")
+	yamlBody := "components:\n  psu:\n    initial: nominal\n    states:\n      nominal:\n        transitions: {}\n"
+	if err := os.WriteFile(yamlPath, []byte(yamlBody), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(yamlPath)
+	if err != nil {
+		t.Fatalf("LoadConfig(yaml): %v", err)
+	}
+	if _, ok := cfg.Components["psu"]; !ok {
+		t.Error("LoadConfig(yaml): missing psu component")
+	}
+
+	jsonPath := filepath.Join(dir, "scenario.json")
+	jsonBody := `{"components":{"psu":{"initial":"nominal","states":{"nominal":{"transitions":{}}}}}}`
+	if err := os.WriteFile(jsonPath, []byte(jsonBody), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(jsonPath); err != nil {
+		t.Fatalf("LoadConfig(json): %v", err)
+	}
+
+	badPath := filepath.Join(dir, "scenario.txt")
+	if err := os.WriteFile(badPath, []byte("components: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(badPath); err == nil {
+		t.Error("LoadConfig with unsupported extension: want error, got nil")
+	}
+
+	badInitialPath := filepath.Join(dir, "bad-initial.yaml")
+	badInitialBody := "components:\n  psu:\n    initial: bogus\n    states:\n      nominal:\n        transitions: {}\n"
+	if err := os.WriteFile(badInitialPath, []byte(badInitialBody), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(badInitialPath); err == nil {
+		t.Error("LoadConfig with unknown initial state: want error, got nil")
+	}
+}
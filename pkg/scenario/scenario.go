@@ -0,0 +1,67 @@
+// Package scenario drives components through named states (GPU
+// idle/training/throttled/failed, fan nominal/degraded/failed, PSU
+// nominal/overcurrent, ...) with Markov-style transition probabilities,
+// instead of the old purely stateless random sampling. Collectors read a
+// component's current state to bias the values they report, and the state
+// can also be forced via Engine.Inject for testing alerting rules.
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StateDef is one named state of a component: the probability, per tick, of
+// transitioning to each other named state. A component stays in its current
+// state on any tick where no transition fires.
+type StateDef struct {
+	Transitions map[string]float64 `yaml:"transitions" json:"transitions"`
+}
+
+// ComponentDef is the state machine for a single simulated component (one
+// GPU, one fan pair, the PSU, ...).
+type ComponentDef struct {
+	Initial string              `yaml:"initial" json:"initial"`
+	States  map[string]StateDef `yaml:"states" json:"states"`
+}
+
+// Config is a full scenario file: one state machine per component, keyed by
+// the component id collectors ask about (e.g. "gpu0", "fan3", "psu").
+type Config struct {
+	Components map[string]ComponentDef `yaml:"components" json:"components"`
+}
+
+// LoadConfig reads a scenario file, choosing YAML or JSON based on the file
+// extension (.yaml/.yml or .json).
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("scenario: reading %s: %w", path, err)
+	}
+
+	var cfg Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &cfg)
+	case ".json":
+		err = json.Unmarshal(data, &cfg)
+	default:
+		return nil, fmt.Errorf("scenario: unsupported scenario file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scenario: parsing %s: %w", path, err)
+	}
+
+	for name, def := range cfg.Components {
+		if _, ok := def.States[def.Initial]; def.Initial != "" && !ok {
+			return nil, fmt.Errorf("scenario: component %q has unknown initial state %q", name, def.Initial)
+		}
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,16 @@
+package topology
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// FakeUUID deterministically derives a GPU-UUID-shaped string from seed
+// (e.g. "g014-gpu2"), so the same node/index always reports the same
+// identity across restarts without having to store it anywhere.
+func FakeUUID(seed string) string {
+	h := fnv.New128a()
+	h.Write([]byte(seed))
+	sum := h.Sum(nil)
+	return fmt.Sprintf("GPU-%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,138 @@
+// Package topology describes a simulated cluster of nodes, each with its
+// own hostname, GPU fleet and fan/PSU layout, so one process can serve
+// metrics for many synthetic BMCs and GPU hosts the way the blackbox and
+// snmp exporters serve many real targets from one process.
+package topology
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GPUConfig describes the GPU fleet attached to one node.
+type GPUConfig struct {
+	Count  int    `yaml:"count" json:"count"`
+	Model  string `yaml:"model" json:"model"`
+	Vendor string `yaml:"vendor" json:"vendor"` // "nvidia", "amd" or "mixed"
+
+	// MIG optionally partitions some of this node's GPUs into MIG
+	// instances. A GPU with no MIG entries reports only its whole-device
+	// rows. Unset (the common case) means this node has no MIG-partitioned
+	// GPUs at all.
+	MIG []MIGInstanceConfig `yaml:"mig" json:"mig"`
+}
+
+// MIGInstanceConfig describes one MIG partition configured on a GPU.
+type MIGInstanceConfig struct {
+	GPUIndex   int     `yaml:"gpu_index" json:"gpu_index"`
+	Profile    string  `yaml:"profile" json:"profile"`
+	ShareOfGPU float64 `yaml:"share_of_gpu" json:"share_of_gpu"`
+}
+
+// FanConfig describes a node's fan layout.
+type FanConfig struct {
+	Pairs int `yaml:"pairs" json:"pairs"` // number of A/B fan pairs
+}
+
+// NodeConfig is one synthetic node: its identity, GPU fleet and fan layout.
+type NodeConfig struct {
+	Hostname   string    `yaml:"hostname" json:"hostname"`
+	BMCAddress string    `yaml:"bmc_address" json:"bmc_address"`
+	Fans       FanConfig `yaml:"fans" json:"fans"`
+	GPUs       GPUConfig `yaml:"gpus" json:"gpus"`
+}
+
+// Config is a full topology file: the fleet of synthetic nodes a single
+// process should serve metrics for.
+type Config struct {
+	Nodes []NodeConfig `yaml:"nodes" json:"nodes"`
+}
+
+// defaultNode reproduces the tool's original single-host behavior (4 A100s,
+// 18 fan pairs, GPU 3 split into one 3g.40gb and two 1g.10gb MIG instances),
+// used when no --topology file is given.
+func defaultNode(hostname string) NodeConfig {
+	return NodeConfig{
+		Hostname: hostname,
+		Fans:     FanConfig{Pairs: 18},
+		GPUs: GPUConfig{
+			Count:  4,
+			Model:  "NVIDIA A100-SXM4-80GB",
+			Vendor: "nvidia",
+			MIG: []MIGInstanceConfig{
+				{GPUIndex: 3, Profile: "3g.40gb", ShareOfGPU: 0.5},
+				{GPUIndex: 3, Profile: "1g.10gb", ShareOfGPU: 0.125},
+				{GPUIndex: 3, Profile: "1g.10gb", ShareOfGPU: 0.125},
+			},
+		},
+	}
+}
+
+// DefaultConfig returns a single-node Config matching the tool's original
+// hardcoded behavior.
+func DefaultConfig(hostname string) Config {
+	return Config{Nodes: []NodeConfig{defaultNode(hostname)}}
+}
+
+// LoadConfig reads a topology file, choosing YAML or JSON based on the file
+// extension (.yaml/.yml or .json), and fills in defaults for a node's fan
+// pair count, GPU vendor and GPU model when left unset. Other node fields
+// get no default.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("topology: reading %s: %w", path, err)
+	}
+
+	var cfg Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &cfg)
+	case ".json":
+		err = json.Unmarshal(data, &cfg)
+	default:
+		return nil, fmt.Errorf("topology: unsupported topology file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("topology: parsing %s: %w", path, err)
+	}
+
+	seenHostnames := make(map[string]bool, len(cfg.Nodes))
+	for i, node := range cfg.Nodes {
+		if node.Hostname == "" {
+			return nil, fmt.Errorf("topology: node %d is missing a hostname", i)
+		}
+		if seenHostnames[node.Hostname] {
+			return nil, fmt.Errorf("topology: duplicate node hostname %q", node.Hostname)
+		}
+		seenHostnames[node.Hostname] = true
+		if node.Fans.Pairs < 0 {
+			return nil, fmt.Errorf("topology: node %q has a negative fan pair count", node.Hostname)
+		}
+		if node.Fans.Pairs == 0 {
+			node.Fans.Pairs = 18
+		}
+		if node.GPUs.Count < 0 {
+			return nil, fmt.Errorf("topology: node %q has a negative GPU count", node.Hostname)
+		}
+		if node.GPUs.Vendor == "" {
+			node.GPUs.Vendor = "nvidia"
+		}
+		if node.GPUs.Model == "" {
+			node.GPUs.Model = "NVIDIA A100-SXM4-80GB"
+		}
+		for _, m := range node.GPUs.MIG {
+			if m.GPUIndex < 0 || m.GPUIndex >= node.GPUs.Count {
+				return nil, fmt.Errorf("topology: node %q has a mig entry for gpu_index %d, out of range for %d GPUs", node.Hostname, m.GPUIndex, node.GPUs.Count)
+			}
+		}
+		cfg.Nodes[i] = node
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,129 @@
+package topology
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig("g001")
+	if len(cfg.Nodes) != 1 {
+		t.Fatalf("len(cfg.Nodes) = %d, want 1", len(cfg.Nodes))
+	}
+	node := cfg.Nodes[0]
+	if node.Hostname != "g001" {
+		t.Errorf("Hostname = %q, want g001", node.Hostname)
+	}
+	if node.Fans.Pairs != 18 {
+		t.Errorf("Fans.Pairs = %d, want 18", node.Fans.Pairs)
+	}
+	if node.GPUs.Count != 4 || node.GPUs.Vendor != "nvidia" {
+		t.Errorf("GPUs = %+v, want Count=4 Vendor=nvidia", node.GPUs)
+	}
+	if len(node.GPUs.MIG) != 3 {
+		t.Errorf("len(GPUs.MIG) = %d, want 3", len(node.GPUs.MIG))
+	}
+}
+
+func TestLoadConfigFillsDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "topology.yaml")
+	body := "nodes:\n  - hostname: g005\n"
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	node := cfg.Nodes[0]
+	if node.Fans.Pairs != 18 {
+		t.Errorf("Fans.Pairs = %d, want default 18", node.Fans.Pairs)
+	}
+	if node.GPUs.Vendor != "nvidia" {
+		t.Errorf("GPUs.Vendor = %q, want default nvidia", node.GPUs.Vendor)
+	}
+	if node.GPUs.Model != "NVIDIA A100-SXM4-80GB" {
+		t.Errorf("GPUs.Model = %q, want default A100", node.GPUs.Model)
+	}
+}
+
+func TestLoadConfigRejectsMissingHostname(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "topology.yaml")
+	body := "nodes:\n  - fans:\n      pairs: 10\n"
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("LoadConfig with no hostname: want error, got nil")
+	}
+}
+
+func TestLoadConfigRejectsDuplicateHostname(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "topology.yaml")
+	body := "nodes:\n  - hostname: g001\n  - hostname: g001\n"
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("LoadConfig with duplicate hostname: want error, got nil")
+	}
+}
+
+func TestLoadConfigRejectsNegativeCounts(t *testing.T) {
+	dir := t.TempDir()
+
+	fansPath := filepath.Join(dir, "negative-fans.yaml")
+	if err := os.WriteFile(fansPath, []byte("nodes:\n  - hostname: g001\n    fans:\n      pairs: -1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(fansPath); err == nil {
+		t.Error("LoadConfig with negative fan pairs: want error, got nil")
+	}
+
+	gpusPath := filepath.Join(dir, "negative-gpus.yaml")
+	if err := os.WriteFile(gpusPath, []byte("nodes:\n  - hostname: g001\n    gpus:\n      count: -1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(gpusPath); err == nil {
+		t.Error("LoadConfig with negative GPU count: want error, got nil")
+	}
+}
+
+func TestLoadConfigRejectsOutOfRangeMIGGPUIndex(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "topology.yaml")
+	body := "nodes:\n  - hostname: g001\n    gpus:\n      count: 2\n      mig:\n        - gpu_index: 3\n          profile: 1g.10gb\n          share_of_gpu: 0.125\n"
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("LoadConfig with out-of-range mig gpu_index: want error, got nil")
+	}
+}
+
+func TestLoadConfigRejectsUnsupportedExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "topology.txt")
+	if err := os.WriteFile(path, []byte("nodes: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("LoadConfig with unsupported extension: want error, got nil")
+	}
+}
+
+func TestFakeUUIDIsDeterministicAndDistinct(t *testing.T) {
+	a := FakeUUID("g001-gpu0")
+	b := FakeUUID("g001-gpu0")
+	if a != b {
+		t.Errorf("FakeUUID is not deterministic: %q != %q", a, b)
+	}
+	if c := FakeUUID("g001-gpu1"); c == a {
+		t.Errorf("FakeUUID(g001-gpu1) collided with FakeUUID(g001-gpu0): %q", c)
+	}
+}
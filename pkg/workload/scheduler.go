@@ -0,0 +1,88 @@
+// Package workload simulates a Kubernetes scheduler assigning synthetic
+// pods to the fake GPUs and BMCs so scraped metrics carry realistic
+// pod/namespace/container attribution, the way nvidia-dcgm-exporter does
+// when wired to the k8s device plugin.
+package workload
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Workload is a synthetic pod attributed to a resource for the duration of
+// its lifetime. The zero value means the resource is currently idle.
+type Workload struct {
+	Pod               string
+	Namespace         string
+	Container         string
+	ExportedContainer string
+}
+
+var (
+	namespaces = []string{"ml-team", "research", "prod-inference"}
+	profiles   = []string{"training", "inference", "batch"}
+)
+
+type assignment struct {
+	workload Workload
+	expires  time.Time
+}
+
+// Scheduler assigns synthetic workloads to a fixed set of resource ids
+// (e.g. "gpu0", "ipmi-power"). Each assignment has a lifetime; once it
+// expires the resource goes idle until a new workload happens to arrive.
+type Scheduler struct {
+	mu          sync.Mutex
+	assignments map[string]assignment
+}
+
+// NewScheduler returns an empty Scheduler; every resource starts idle.
+func NewScheduler() *Scheduler {
+	return &Scheduler{assignments: make(map[string]assignment)}
+}
+
+// Tick expires workloads whose lifetime has ended and, for each now-idle
+// resource id, has a small chance of scheduling a fresh one.
+func (s *Scheduler) Tick(resourceIDs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, id := range resourceIDs {
+		if a, ok := s.assignments[id]; ok {
+			if now.Before(a.expires) {
+				continue
+			}
+			delete(s.assignments, id)
+		}
+		if rand.Float64() < 0.01 { // arrival chance per tick
+			s.assignments[id] = newAssignment(id)
+		}
+	}
+}
+
+// newAssignment builds a workload with a random namespace, profile-derived
+// pod name, and a lifetime of 5-60 minutes.
+func newAssignment(resourceID string) assignment {
+	profile := profiles[rand.Intn(len(profiles))]
+	container := "gpu-worker"
+	return assignment{
+		workload: Workload{
+			Pod:               fmt.Sprintf("%s-%s-%x", profile, resourceID, rand.Int31()),
+			Namespace:         namespaces[rand.Intn(len(namespaces))],
+			Container:         container,
+			ExportedContainer: container,
+		},
+		expires: time.Now().Add(time.Duration(5+rand.Intn(56)) * time.Minute),
+	}
+}
+
+// Assignment returns the workload currently attributed to resourceID, or
+// the zero Workload if it's idle.
+func (s *Scheduler) Assignment(resourceID string) Workload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.assignments[resourceID].workload
+}
@@ -0,0 +1,35 @@
+package workload
+
+import "testing"
+
+func TestAssignmentIdleByDefault(t *testing.T) {
+	s := NewScheduler()
+	if got := s.Assignment("gpu0"); got != (Workload{}) {
+		t.Errorf("Assignment(gpu0) on a fresh scheduler = %+v, want zero value", got)
+	}
+}
+
+func TestTickEventuallySchedulesAWorkload(t *testing.T) {
+	s := NewScheduler()
+	ids := []string{"gpu0"}
+
+	// The arrival chance is 1% per tick, so across 2000 ticks the odds of
+	// never seeing an arrival are astronomically small.
+	for i := 0; i < 2000; i++ {
+		s.Tick(ids)
+		if got := s.Assignment("gpu0"); got.Pod != "" {
+			return
+		}
+	}
+	t.Fatal("gpu0 never received a workload after 2000 ticks")
+}
+
+func TestTickOnlyTouchesListedResources(t *testing.T) {
+	s := NewScheduler()
+	for i := 0; i < 2000; i++ {
+		s.Tick([]string{"gpu0"})
+	}
+	if got := s.Assignment("gpu1"); got != (Workload{}) {
+		t.Errorf("Assignment(gpu1), which was never ticked, = %+v, want zero value", got)
+	}
+}